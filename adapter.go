@@ -0,0 +1,175 @@
+package lambdahandler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Request is the normalized request passed to every HandlerFunc, decoded
+// from whichever Lambda trigger event the router was invoked with.
+type Request struct {
+	Method          string
+	Path            string
+	Headers         map[string]string
+	QueryParams     map[string]string
+	Body            string
+	IsBase64Encoded bool
+	RequestID       string
+}
+
+// Response is the normalized response every HandlerFunc pipeline produces,
+// re-encoded into the trigger-specific response type by an EventAdapter.
+type Response struct {
+	StatusCode      int
+	Headers         map[string]string
+	Body            string
+	IsBase64Encoded bool
+}
+
+// EventAdapter translates between a Lambda trigger's event/response types
+// and the router's normalized Request/Response, so one handler package can
+// serve a Function URL, API Gateway, or ALB without rewriting handlers.
+type EventAdapter[TReq any, TResp any] interface {
+	Decode(event TReq) Request
+	Encode(resp Response) TResp
+}
+
+// HandleEvent decodes event with adapter, dispatches it through the
+// router's routing and middleware pipeline, and encodes the result back
+// into the trigger-specific response type.
+func HandleEvent[TReq any, TResp any](r *Router, ctx context.Context, event TReq, adapter EventAdapter[TReq, TResp]) (TResp, error) {
+	req := adapter.Decode(event)
+	resp := r.handle(ctx, req)
+	return adapter.Encode(resp), nil
+}
+
+// functionURLAdapter adapts Lambda Function URL events.
+type functionURLAdapter struct{}
+
+func (functionURLAdapter) Decode(event events.LambdaFunctionURLRequest) Request {
+	return Request{
+		Method:          event.RequestContext.HTTP.Method,
+		Path:            event.RequestContext.HTTP.Path,
+		Headers:         event.Headers,
+		QueryParams:     event.QueryStringParameters,
+		Body:            event.Body,
+		IsBase64Encoded: event.IsBase64Encoded,
+		RequestID:       event.RequestContext.RequestID,
+	}
+}
+
+func (functionURLAdapter) Encode(resp Response) events.LambdaFunctionURLResponse {
+	return events.LambdaFunctionURLResponse{
+		StatusCode:      resp.StatusCode,
+		Headers:         resp.Headers,
+		Body:            resp.Body,
+		IsBase64Encoded: resp.IsBase64Encoded,
+	}
+}
+
+// apiGatewayAdapter adapts API Gateway REST API (v1) proxy events.
+type apiGatewayAdapter struct{}
+
+func (apiGatewayAdapter) Decode(event events.APIGatewayProxyRequest) Request {
+	return Request{
+		Method:          event.HTTPMethod,
+		Path:            event.Path,
+		Headers:         normalizeHeaderKeys(event.Headers),
+		QueryParams:     event.QueryStringParameters,
+		Body:            event.Body,
+		IsBase64Encoded: event.IsBase64Encoded,
+		RequestID:       event.RequestContext.RequestID,
+	}
+}
+
+func (apiGatewayAdapter) Encode(resp Response) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		StatusCode:      resp.StatusCode,
+		Headers:         resp.Headers,
+		Body:            resp.Body,
+		IsBase64Encoded: resp.IsBase64Encoded,
+	}
+}
+
+// apiGatewayV2Adapter adapts API Gateway HTTP API (v2) events.
+type apiGatewayV2Adapter struct{}
+
+func (apiGatewayV2Adapter) Decode(event events.APIGatewayV2HTTPRequest) Request {
+	return Request{
+		Method:          event.RequestContext.HTTP.Method,
+		Path:            event.RequestContext.HTTP.Path,
+		Headers:         event.Headers,
+		QueryParams:     event.QueryStringParameters,
+		Body:            event.Body,
+		IsBase64Encoded: event.IsBase64Encoded,
+		RequestID:       event.RequestContext.RequestID,
+	}
+}
+
+func (apiGatewayV2Adapter) Encode(resp Response) events.APIGatewayV2HTTPResponse {
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode:      resp.StatusCode,
+		Headers:         resp.Headers,
+		Body:            resp.Body,
+		IsBase64Encoded: resp.IsBase64Encoded,
+	}
+}
+
+// albAdapter adapts Application Load Balancer target group events.
+type albAdapter struct{}
+
+func (albAdapter) Decode(event events.ALBTargetGroupRequest) Request {
+	return Request{
+		Method:          event.HTTPMethod,
+		Path:            event.Path,
+		Headers:         normalizeHeaderKeys(event.Headers),
+		QueryParams:     event.QueryStringParameters,
+		Body:            event.Body,
+		IsBase64Encoded: event.IsBase64Encoded,
+	}
+}
+
+// normalizeHeaderKeys lowercases header names. Function URL and API Gateway
+// v2 payloads already normalize header casing, but REST API (v1) and ALB
+// events preserve the client's original casing, and the router's CORS
+// handling looks headers up by their lowercase name (e.g. "origin").
+func normalizeHeaderKeys(headers map[string]string) map[string]string {
+	normalized := make(map[string]string, len(headers))
+	for k, v := range headers {
+		normalized[strings.ToLower(k)] = v
+	}
+	return normalized
+}
+
+func (albAdapter) Encode(resp Response) events.ALBTargetGroupResponse {
+	return events.ALBTargetGroupResponse{
+		StatusCode:        resp.StatusCode,
+		StatusDescription: http.StatusText(resp.StatusCode),
+		Headers:           resp.Headers,
+		Body:              resp.Body,
+		IsBase64Encoded:   resp.IsBase64Encoded,
+	}
+}
+
+// HandleFunctionURL serves a Lambda Function URL event.
+func (r *Router) HandleFunctionURL(ctx context.Context, event events.LambdaFunctionURLRequest) (events.LambdaFunctionURLResponse, error) {
+	return HandleEvent(r, ctx, event, functionURLAdapter{})
+}
+
+// HandleAPIGateway serves an API Gateway REST API (v1) proxy event.
+func (r *Router) HandleAPIGateway(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return HandleEvent(r, ctx, event, apiGatewayAdapter{})
+}
+
+// HandleAPIGatewayV2 serves an API Gateway HTTP API (v2) event.
+func (r *Router) HandleAPIGatewayV2(ctx context.Context, event events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	return HandleEvent(r, ctx, event, apiGatewayV2Adapter{})
+}
+
+// HandleALB serves an Application Load Balancer target group event.
+func (r *Router) HandleALB(ctx context.Context, event events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+	return HandleEvent(r, ctx, event, albAdapter{})
+}
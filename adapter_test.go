@@ -0,0 +1,30 @@
+package lambdahandler
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// TestAPIGatewayAdapterNormalizesHeaderCasing is a regression test for a
+// bug where REST API (v1) events, which preserve the client's original
+// header casing, were passed through to Request.Headers verbatim. Since
+// CORS handling looks headers up by lowercase name (e.g. "origin"), a
+// client-cased "Origin" header was silently invisible to it.
+func TestAPIGatewayAdapterNormalizesHeaderCasing(t *testing.T) {
+	req := apiGatewayAdapter{}.Decode(events.APIGatewayProxyRequest{
+		Headers: map[string]string{"Origin": "https://app.example.com"},
+	})
+	if req.Headers["origin"] != "https://app.example.com" {
+		t.Errorf("expected lowercase 'origin' key, got %v", req.Headers)
+	}
+}
+
+func TestALBAdapterNormalizesHeaderCasing(t *testing.T) {
+	req := albAdapter{}.Decode(events.ALBTargetGroupRequest{
+		Headers: map[string]string{"Origin": "https://app.example.com"},
+	})
+	if req.Headers["origin"] != "https://app.example.com" {
+		t.Errorf("expected lowercase 'origin' key, got %v", req.Headers)
+	}
+}
@@ -0,0 +1,135 @@
+package lambdahandler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var bindValidator = validator.New()
+
+// BindJSON decodes req's body (transparently base64-decoding it when
+// req.IsBase64Encoded) as JSON into v, then validates it against any
+// `validate` struct tags. It returns a 400 LambdaError with per-field
+// details on failure, eliminating the usual
+// json.Unmarshal([]byte(req.Body), ...) plus manual required-field checks.
+func BindJSON(req Request, v interface{}) LambdaError {
+	body := req.Body
+	if req.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(body)
+		if err != nil {
+			return BadRequest("invalid base64-encoded body")
+		}
+		body = string(decoded)
+	}
+
+	if err := json.Unmarshal([]byte(body), v); err != nil {
+		return BadRequest(fmt.Sprintf("invalid request body: %s", err))
+	}
+
+	return validateStruct(v)
+}
+
+// BindQuery populates v's fields tagged `query:"name"` from req's query
+// string parameters, then validates any `validate` struct tags.
+func BindQuery(req Request, v interface{}) LambdaError {
+	if err := bindTagged(req.QueryParams, v, "query"); err != nil {
+		return BadRequest(err.Error())
+	}
+	return validateStruct(v)
+}
+
+// Bind populates v's fields tagged `param:"name"` from the route's
+// extracted URL parameters, then validates any `validate` struct tags.
+func (p Params) Bind(v interface{}) LambdaError {
+	if err := bindTagged(p, v, "param"); err != nil {
+		return BadRequest(err.Error())
+	}
+	return validateStruct(v)
+}
+
+// bindTagged assigns values from data into v's fields by tag name, falling
+// back to the Go field name when the tag is absent.
+func bindTagged(data map[string]string, v interface{}, tag string) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a pointer to a struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported field: not settable via reflection
+		}
+		name := field.Tag.Get(tag)
+		if name == "" {
+			name = field.Name
+		}
+		if name == "-" {
+			continue
+		}
+		raw, ok := data[name]
+		if !ok {
+			continue
+		}
+		if err := setField(rv.Field(i), raw); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setField assigns raw to a struct field of a supported kind.
+func setField(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Kind())
+	}
+	return nil
+}
+
+// validateStruct runs go-playground/validator over v, surfacing any
+// `validate` tag failures as a 400 LambdaError with per-field details.
+func validateStruct(v interface{}) LambdaError {
+	err := bindValidator.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	validationErrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return BadRequest(err.Error())
+	}
+
+	details := make(map[string]string, len(validationErrs))
+	for _, fe := range validationErrs {
+		details[fe.Field()] = fmt.Sprintf("failed on the '%s' tag", fe.Tag())
+	}
+	return WithDetails(BadRequest("validation failed"), details)
+}
@@ -0,0 +1,25 @@
+package lambdahandler
+
+import "testing"
+
+// TestParamsBindSkipsUnexportedFields is a regression test for a panic when
+// a bind target's unexported field name happened to match an incoming
+// param/query key: reflection can't set unexported fields, and the code
+// used to try anyway.
+func TestParamsBindSkipsUnexportedFields(t *testing.T) {
+	type target struct {
+		name string
+		ID   string `param:"id"`
+	}
+
+	var dst target
+	if err := (Params{"name": "bob", "id": "42"}).Bind(&dst); err != nil {
+		t.Fatalf("unexpected error: %v", err.Message())
+	}
+	if dst.ID != "42" {
+		t.Errorf("expected exported field to be set, got %q", dst.ID)
+	}
+	if dst.name != "" {
+		t.Errorf("expected unexported field to be left untouched, got %q", dst.name)
+	}
+}
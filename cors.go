@@ -0,0 +1,207 @@
+package lambdahandler
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig describes the CORS policy in effect for a router or a route
+// group subtree, modeled after the shape popularized by rs/cors.
+type CORSConfig struct {
+	Origins          []string                 // Allowed origins; entries may contain "*" wildcards (e.g. "https://*.example.com")
+	Methods          []string                 // Allowed methods, default is all methods
+	Headers          []string                 // Allowed request headers, default is all headers
+	ExposeHeaders    []string                 // Response headers browsers are allowed to read
+	AllowOriginFunc  func(origin string) bool // Optional dynamic origin check; overrides Origins when set
+	AllowCredentials bool                     // Forces echoing the exact origin and sets Access-Control-Allow-Credentials
+	MaxAge           time.Duration            // How long browsers may cache a preflight response
+}
+
+// corsConfig returns the router's top-level CORS configuration.
+func (r *Router) corsConfig() *CORSConfig {
+	return &r.CORSConfig
+}
+
+// effectiveCORS returns the CORS configuration that applies to route,
+// falling back to the router's top-level settings when the route (or its
+// group) didn't override them.
+func (r *Router) effectiveCORS(route *Route) *CORSConfig {
+	if route != nil && route.Group != nil {
+		return route.Group.effectiveCORS()
+	}
+	return r.corsConfig()
+}
+
+// corsPreflightResponse handles preflight CORS requests
+func (r *Router) corsPreflightResponse(req Request) Response {
+	cors := r.effectiveCORS(r.routeForCORS(req.Path, req.Headers["access-control-request-method"]))
+
+	origin := req.Headers["origin"]
+	if !isOriginAllowed(cors, origin) {
+		return Response{
+			StatusCode: 403,
+			Body:       "Origin not allowed",
+		}
+	}
+	if reqMethod := req.Headers["access-control-request-method"]; reqMethod != "" && !isMethodAllowed(cors, reqMethod) {
+		return Response{
+			StatusCode: 403,
+			Body:       "Method not allowed",
+		}
+	}
+	if reqHeaders := req.Headers["access-control-request-headers"]; reqHeaders != "" && !areHeadersAllowed(cors, reqHeaders) {
+		return Response{
+			StatusCode: 403,
+			Body:       "Headers not allowed",
+		}
+	}
+
+	headers := map[string]string{
+		"Access-Control-Allow-Origin":  resolveAllowOrigin(cors, origin),
+		"Access-Control-Allow-Methods": strings.Join(cors.Methods, ", "),
+		"Access-Control-Allow-Headers": strings.Join(cors.Headers, ", "),
+	}
+	if cors.AllowCredentials {
+		headers["Access-Control-Allow-Credentials"] = "true"
+	}
+	if cors.MaxAge > 0 {
+		headers["Access-Control-Max-Age"] = strconv.Itoa(int(cors.MaxAge.Seconds()))
+	}
+
+	return Response{
+		StatusCode: 200,
+		Headers:    headers,
+	}
+}
+
+// routeForCORS finds the route whose group CORS override should apply to a
+// preflight request against path (where the real route can't be matched by
+// method, since the request method is OPTIONS). When multiple methods are
+// registered for path under different groups, preferredMethod (normally the
+// preflight's Access-Control-Request-Method) picks among them; if it's
+// absent or doesn't match any registered route, the lowest method name is
+// picked deterministically rather than an arbitrary map iteration order.
+func (r *Router) routeForCORS(path, preferredMethod string) *Route {
+	if preferredMethod != "" {
+		if node, _, _ := r.trie.lookup(preferredMethod, path); node != nil {
+			return node.routes[preferredMethod]
+		}
+	}
+
+	routes := r.trie.routesForPath(path)
+	if len(routes) == 0 {
+		return nil
+	}
+	methods := make([]string, 0, len(routes))
+	for m := range routes {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return routes[methods[0]]
+}
+
+// attachCORSHeaders adds CORS headers to responses, using route's group
+// override when present and falling back to the router's top-level config.
+func (r *Router) attachCORSHeaders(response *Response, req Request, route *Route) {
+	cors := r.effectiveCORS(route)
+	origin := req.Headers["origin"]
+
+	if response.Headers == nil {
+		response.Headers = make(map[string]string)
+	}
+	response.Headers["Access-Control-Allow-Origin"] = resolveAllowOrigin(cors, origin)
+	response.Headers["Access-Control-Allow-Methods"] = strings.Join(cors.Methods, ", ")
+	response.Headers["Access-Control-Allow-Headers"] = strings.Join(cors.Headers, ", ")
+	if cors.AllowCredentials {
+		response.Headers["Access-Control-Allow-Credentials"] = "true"
+	}
+	if len(cors.ExposeHeaders) > 0 {
+		response.Headers["Access-Control-Expose-Headers"] = strings.Join(cors.ExposeHeaders, ", ")
+	}
+}
+
+// resolveAllowOrigin picks the Access-Control-Allow-Origin value for an
+// allowed origin: the literal "*" when the config allows any origin and
+// credentials aren't in play, otherwise the exact requesting origin (which
+// credentialed responses require).
+func resolveAllowOrigin(cors *CORSConfig, origin string) string {
+	if origin == "" || !isOriginAllowed(cors, origin) {
+		return "*"
+	}
+	if !cors.AllowCredentials && cors.AllowOriginFunc == nil && len(cors.Origins) == 1 && cors.Origins[0] == "*" {
+		return "*"
+	}
+	return origin
+}
+
+// isOriginAllowed checks if origin is allowed under cors.
+func isOriginAllowed(cors *CORSConfig, origin string) bool {
+	if cors.AllowOriginFunc != nil {
+		return cors.AllowOriginFunc(origin)
+	}
+	if len(cors.Origins) == 1 && cors.Origins[0] == "*" {
+		return true
+	}
+	for _, allowedOrigin := range cors.Origins {
+		if allowedOrigin == origin {
+			return true
+		}
+		if strings.Contains(allowedOrigin, "*") && matchWildcardOrigin(allowedOrigin, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcardOrigin reports whether origin matches pattern, where "*" in
+// pattern stands for any run of non-dot characters (e.g. a subdomain label
+// in "https://*.example.com").
+func matchWildcardOrigin(pattern, origin string) bool {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, `[^.]+`)
+	matched, err := regexp.MatchString("^"+quoted+"$", origin)
+	return err == nil && matched
+}
+
+// isMethodAllowed checks the preflight-requested method against cors.Methods.
+func isMethodAllowed(cors *CORSConfig, method string) bool {
+	if len(cors.Methods) == 1 && cors.Methods[0] == "*" {
+		return true
+	}
+	for _, allowed := range cors.Methods {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// areHeadersAllowed checks every header named in the comma-separated
+// Access-Control-Request-Headers value against cors.Headers.
+func areHeadersAllowed(cors *CORSConfig, requested string) bool {
+	if len(cors.Headers) == 1 && cors.Headers[0] == "*" {
+		return true
+	}
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if !containsHeaderFold(cors.Headers, header) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsHeaderFold(headers []string, header string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h, header) {
+			return true
+		}
+	}
+	return false
+}
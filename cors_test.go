@@ -0,0 +1,126 @@
+package lambdahandler
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIsOriginAllowedWildcardPattern(t *testing.T) {
+	cors := &CORSConfig{Origins: []string{"https://*.example.com"}}
+
+	cases := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://app.example.com", true},
+		{"https://api.example.com", true},
+		{"https://app.sub.example.com", false}, // "*" must not span dots
+		{"https://example.com", false},
+		{"https://evil.com", false},
+	}
+	for _, tc := range cases {
+		if got := isOriginAllowed(cors, tc.origin); got != tc.want {
+			t.Errorf("isOriginAllowed(%q) = %v, want %v", tc.origin, got, tc.want)
+		}
+	}
+}
+
+func TestIsOriginAllowedAllowOriginFuncOverridesList(t *testing.T) {
+	cors := &CORSConfig{
+		Origins:         []string{"https://allowed.example.com"},
+		AllowOriginFunc: func(origin string) bool { return origin == "https://dynamic.example.com" },
+	}
+
+	if isOriginAllowed(cors, "https://allowed.example.com") {
+		t.Error("expected AllowOriginFunc to override Origins list")
+	}
+	if !isOriginAllowed(cors, "https://dynamic.example.com") {
+		t.Error("expected AllowOriginFunc to allow its own origin")
+	}
+}
+
+func TestResolveAllowOriginEchoesExactOriginWithCredentials(t *testing.T) {
+	cors := &CORSConfig{Origins: []string{"*"}, AllowCredentials: true}
+
+	got := resolveAllowOrigin(cors, "https://app.example.com")
+	if got != "https://app.example.com" {
+		t.Errorf("expected exact origin echoed back for credentialed wildcard config, got %q", got)
+	}
+}
+
+// TestRouteGroupCORSAppliesRegardlessOfRegistrationOrder is a regression
+// test for a bug where RouteGroup.CORS only took effect for routes
+// registered after it was called, because AddRoute snapshotted the CORS
+// config at registration time instead of resolving it lazily.
+func TestRouteGroupCORSAppliesRegardlessOfRegistrationOrder(t *testing.T) {
+	r := NewRouter()
+	admin := r.Group("/admin")
+	admin.Get("/first", ok) // registered before CORS() is called
+	admin.CORS(CORSConfig{Origins: []string{"https://admin.example.com"}})
+	admin.Get("/second", ok) // registered after CORS() is called
+
+	for _, path := range []string{"/admin/first", "/admin/second"} {
+		resp := r.handle(context.Background(), Request{
+			Method:  "GET",
+			Path:    path,
+			Headers: map[string]string{"origin": "https://admin.example.com"},
+		})
+		if got := resp.Headers["Access-Control-Allow-Origin"]; got != "https://admin.example.com" {
+			t.Errorf("path %s: expected strict CORS origin echoed, got %q", path, got)
+		}
+	}
+}
+
+// TestPreflightUsesRequestedMethodToPickGroupCORS is a regression test for
+// a bug where routeForCORS picked an arbitrary route from a Go map when
+// multiple methods were registered for the same path under different
+// groups, so preflight CORS resolution was nondeterministic. It must
+// instead prefer the route named by Access-Control-Request-Method.
+func TestPreflightUsesRequestedMethodToPickGroupCORS(t *testing.T) {
+	r := NewRouter()
+	public := r.Group("/shared")
+	public.CORS(CORSConfig{Origins: []string{"https://public.example.com"}, Methods: []string{"*"}, Headers: []string{"*"}})
+	public.Get("/thing", ok)
+
+	admin := r.Group("/shared")
+	admin.CORS(CORSConfig{Origins: []string{"https://admin.example.com"}, Methods: []string{"*"}, Headers: []string{"*"}})
+	admin.Post("/thing", ok)
+
+	for _, tc := range []struct {
+		method     string
+		wantOrigin string
+	}{
+		{"GET", "https://public.example.com"},
+		{"POST", "https://admin.example.com"},
+	} {
+		resp := r.handle(context.Background(), Request{
+			Method: "OPTIONS",
+			Path:   "/shared/thing",
+			Headers: map[string]string{
+				"origin":                        tc.wantOrigin,
+				"access-control-request-method": tc.method,
+			},
+		})
+		if got := resp.Headers["Access-Control-Allow-Origin"]; got != tc.wantOrigin {
+			t.Errorf("preflight for %s: expected origin %q picked via requested method, got %q", tc.method, tc.wantOrigin, got)
+		}
+	}
+}
+
+func TestNestedGroupInheritsParentCORSDynamically(t *testing.T) {
+	r := NewRouter()
+	admin := r.Group("/admin")
+	reports := admin.Group("/reports")
+	reports.Get("/ping", ok) // registered before the parent's CORS() is called
+
+	admin.CORS(CORSConfig{Origins: []string{"https://admin.example.com"}})
+
+	resp := r.handle(context.Background(), Request{
+		Method:  "GET",
+		Path:    "/admin/reports/ping",
+		Headers: map[string]string{"origin": "https://admin.example.com"},
+	})
+	if got := resp.Headers["Access-Control-Allow-Origin"]; got != "https://admin.example.com" {
+		t.Errorf("expected nested group to inherit parent's CORS override dynamically, got %q", got)
+	}
+}
@@ -0,0 +1,132 @@
+package lambdahandler
+
+import "encoding/json"
+
+// LambdaError is the error type returned by a HandlerFunc; it carries an
+// HTTP status code alongside a message so handlers don't need to build
+// response bodies themselves.
+type LambdaError interface {
+	Code() int       // Returns the HTTP status code
+	Message() string // Returns the error message
+}
+
+// DetailedError is optionally implemented by a LambdaError that carries
+// field-level detail (e.g. validation errors). When present, Details() is
+// surfaced as "details" in the JSON error envelope.
+type DetailedError interface {
+	Details() any
+}
+
+// LambdaErrorResponse is the struct that implements the LambdaError interface
+type LambdaErrorResponse struct {
+	CodeValue    int    `json:"code"`    // The error code (e.g., 500, 404)
+	MessageValue string `json:"message"` // A human-readable error message
+	DetailsValue any    `json:"-"`       // Optional field-level detail, e.g. validation errors
+}
+
+// Code returns the error code for LambdaErrorResponse
+func (e *LambdaErrorResponse) Code() int {
+	return e.CodeValue
+}
+
+// Message returns the error message for LambdaErrorResponse
+func (e *LambdaErrorResponse) Message() string {
+	return e.MessageValue
+}
+
+// Details returns the optional field-level detail attached to the error.
+func (e *LambdaErrorResponse) Details() any {
+	return e.DetailsValue
+}
+
+// NewLambdaError is a constructor for creating a new LambdaError with code and message
+func NewLambdaError(code int, message string) LambdaError {
+	return &LambdaErrorResponse{
+		CodeValue:    code,
+		MessageValue: message,
+	}
+}
+
+// WithDetails attaches field-level detail to a LambdaError created by this
+// package, returned as "details" in the JSON error envelope.
+func WithDetails(err LambdaError, details any) LambdaError {
+	if e, ok := err.(*LambdaErrorResponse); ok {
+		e.DetailsValue = details
+		return e
+	}
+	return &LambdaErrorResponse{CodeValue: err.Code(), MessageValue: err.Message(), DetailsValue: details}
+}
+
+// BadRequest returns a 400 LambdaError.
+func BadRequest(message string) LambdaError { return NewLambdaError(400, message) }
+
+// Unauthorized returns a 401 LambdaError.
+func Unauthorized(message string) LambdaError { return NewLambdaError(401, message) }
+
+// Forbidden returns a 403 LambdaError.
+func Forbidden(message string) LambdaError { return NewLambdaError(403, message) }
+
+// NotFound returns a 404 LambdaError.
+func NotFound(message string) LambdaError { return NewLambdaError(404, message) }
+
+// Conflict returns a 409 LambdaError.
+func Conflict(message string) LambdaError { return NewLambdaError(409, message) }
+
+// Internal returns a 500 LambdaError.
+func Internal(message string) LambdaError { return NewLambdaError(500, message) }
+
+// WrapError wraps a standard error as a LambdaError with the given status
+// code, so handlers can surface errors from other packages idiomatically:
+// return nil, lambdahandler.WrapError(err, 500).
+func WrapError(err error, code int) LambdaError {
+	return NewLambdaError(code, err.Error())
+}
+
+// errorEnvelope is the JSON body written for every LambdaError response.
+type errorEnvelope struct {
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+	Details   any    `json:"details,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// jsonHeaders returns the Content-Type header shared by every JSON response.
+func jsonHeaders() map[string]string {
+	return map[string]string{"Content-Type": "application/json"}
+}
+
+// ErrorResponse builds the JSON error envelope for err, tagging it with
+// req's request ID so clients and logs can be correlated.
+func ErrorResponse(req Request, err LambdaError) Response {
+	envelope := errorEnvelope{
+		Code:      err.Code(),
+		Message:   err.Message(),
+		RequestID: req.RequestID,
+	}
+	if d, ok := err.(DetailedError); ok {
+		envelope.Details = d.Details()
+	}
+
+	body, marshalErr := json.Marshal(envelope)
+	if marshalErr != nil {
+		body, _ = json.Marshal(errorEnvelope{
+			Code:      500,
+			Message:   "failed to marshal error response",
+			RequestID: req.RequestID,
+		})
+		return Response{StatusCode: 500, Headers: jsonHeaders(), Body: string(body)}
+	}
+
+	return Response{StatusCode: err.Code(), Headers: jsonHeaders(), Body: string(body)}
+}
+
+// SuccessResponse marshals data as the JSON response body. If data can't be
+// marshaled, it falls back to a 500 JSON error instead of returning an
+// empty 200 body.
+func SuccessResponse(req Request, data interface{}) Response {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return ErrorResponse(req, Internal("failed to marshal response body"))
+	}
+	return Response{StatusCode: 200, Headers: jsonHeaders(), Body: string(body)}
+}
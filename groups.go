@@ -0,0 +1,97 @@
+package lambdahandler
+
+// RouteGroup is a set of routes sharing a path prefix, a middleware chain,
+// and (optionally) a CORS override for their subtree. Groups can be nested
+// via Group, inheriting their parent's prefix and middleware.
+//
+// Routes store a reference to their owning group rather than a snapshot of
+// its CORS override, and effectiveCORS resolves the override lazily by
+// walking up to the nearest ancestor that has one set. That makes CORS
+// order-independent: calling CORS after routes were already registered on
+// the group (or on a child group created before the call) still applies to
+// them.
+type RouteGroup struct {
+	router     *Router
+	parent     *RouteGroup
+	prefix     string
+	middleware []Middleware
+	cors       *CORSConfig
+}
+
+// Group creates a RouteGroup whose routes are registered under prefix, with
+// mw running after the router's global middleware and before any route's
+// own middleware.
+func (r *Router) Group(prefix string, mw ...Middleware) *RouteGroup {
+	return &RouteGroup{
+		router:     r,
+		prefix:     prefix,
+		middleware: mw,
+	}
+}
+
+// Group creates a nested RouteGroup under g, inheriting g's prefix and
+// middleware ahead of its own. It inherits g's CORS override dynamically
+// (via effectiveCORS) unless it sets its own.
+func (g *RouteGroup) Group(prefix string, mw ...Middleware) *RouteGroup {
+	return &RouteGroup{
+		router:     g.router,
+		parent:     g,
+		prefix:     g.prefix + prefix,
+		middleware: append(append([]Middleware{}, g.middleware...), mw...),
+	}
+}
+
+// Use appends middleware to the group, run after any middleware already
+// registered on it but before each route's own middleware. It applies to
+// routes registered on g, and child groups created from g, after this call;
+// routes and child groups that already exist keep the middleware they were
+// created with.
+func (g *RouteGroup) Use(mw ...Middleware) {
+	g.middleware = append(g.middleware, mw...)
+}
+
+// CORS overrides the CORS configuration for every route in this group's
+// subtree (including nested groups that don't set their own override, and
+// routes already registered on g before this call).
+func (g *RouteGroup) CORS(cfg CORSConfig) {
+	g.cors = &cfg
+}
+
+// effectiveCORS resolves g's CORS override by walking up to the nearest
+// ancestor group that has one set, falling back to the router's top-level
+// config. Resolving lazily (rather than at route-registration time) is
+// what makes CORS() order-independent.
+func (g *RouteGroup) effectiveCORS() *CORSConfig {
+	for cur := g; cur != nil; cur = cur.parent {
+		if cur.cors != nil {
+			return cur.cors
+		}
+	}
+	return g.router.corsConfig()
+}
+
+// AddRoute registers path (relative to the group's prefix) on the group's
+// router, combining the group's middleware with mw.
+func (g *RouteGroup) AddRoute(method, path string, handler HandlerFunc, mw ...Middleware) {
+	g.router.trie.insert(method, g.prefix+path, &Route{
+		Method:     method,
+		Handler:    handler,
+		Template:   g.prefix + path,
+		Middleware: append(append([]Middleware{}, g.middleware...), mw...),
+		Group:      g,
+	})
+}
+
+// Shortcut methods
+func (g *RouteGroup) Get(path string, handler HandlerFunc, mw ...Middleware) {
+	g.AddRoute("GET", path, handler, mw...)
+}
+func (g *RouteGroup) Post(path string, handler HandlerFunc, mw ...Middleware) {
+	g.AddRoute("POST", path, handler, mw...)
+}
+func (g *RouteGroup) Put(path string, handler HandlerFunc, mw ...Middleware) {
+	g.AddRoute("PUT", path, handler, mw...)
+}
+func (g *RouteGroup) Delete(path string, handler HandlerFunc, mw ...Middleware) {
+	g.AddRoute("DELETE", path, handler, mw...)
+}
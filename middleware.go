@@ -0,0 +1,66 @@
+package lambdahandler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Middleware wraps a HandlerFunc to add cross-cutting behavior (logging,
+// auth, panic recovery, etc.) without touching the handler body itself.
+type Middleware func(HandlerFunc) HandlerFunc
+
+// chain applies mw to h in reverse order, so that mw[0] ends up as the
+// outermost wrapper and runs first.
+func chain(h HandlerFunc, mw ...Middleware) HandlerFunc {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
+
+// Use registers global middleware that runs for every route, ahead of any
+// group or route-level middleware.
+func (r *Router) Use(mw ...Middleware) {
+	r.middleware = append(r.middleware, mw...)
+}
+
+// Recover returns a Middleware that converts a panicking handler into a
+// 500 LambdaError instead of letting the panic escape to the Lambda runtime.
+func Recover() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req Request, params Params) (data interface{}, err LambdaError) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					err = NewLambdaError(500, "internal server error")
+				}
+			}()
+			return next(ctx, req, params)
+		}
+	}
+}
+
+// Logger returns a Middleware that records method, path, latency, and
+// status for every request using the standard log/slog package.
+func Logger() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, req Request, params Params) (interface{}, LambdaError) {
+			start := time.Now()
+			method, path := req.Method, req.Path
+
+			data, err := next(ctx, req, params)
+
+			status := 200
+			if err != nil {
+				status = err.Code()
+			}
+			slog.Info("request",
+				"method", method,
+				"path", path,
+				"status", status,
+				"latency", time.Since(start),
+			)
+			return data, err
+		}
+	}
+}
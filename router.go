@@ -2,192 +2,135 @@ package lambdahandler
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"regexp"
+	"sort"
 	"strings"
-
-	"github.com/aws/aws-lambda-go/events"
 )
 
 // Params represents the extracted URL parameters
 type Params map[string]string
 
-type LambdaError interface {
-	Code() int       // Returns the HTTP status code
-	Message() string // Returns the error message
-}
-
-// ErrorResponse is the struct that implements the LambdaError interface
-type LambdaErrorResponse struct {
-	CodeValue    int    `json:"code"`    // The error code (e.g., 500, 404)
-	MessageValue string `json:"message"` // A human-readable error message
-}
-
-// Code returns the error code for ErrorResponse
-func (e *LambdaErrorResponse) Code() int {
-	return e.CodeValue
-}
+// HandlerFunc defines the type for route handlers. req is the normalized
+// request produced by whichever EventAdapter decoded the incoming trigger
+// event, so the same handler works behind a Function URL, API Gateway, or
+// an ALB.
+type HandlerFunc func(ctx context.Context, req Request, params Params) (interface{}, LambdaError)
 
-// Message returns the error message for ErrorResponse
-func (e *LambdaErrorResponse) Message() string {
-	return e.MessageValue
+// Route holds information about a single route
+type Route struct {
+	Method       string
+	Handler      HandlerFunc
+	Template     string
+	Middleware   []Middleware
+	Group        *RouteGroup // non-nil if the route was registered through a RouteGroup; its CORS override (if any) is resolved lazily via Group.effectiveCORS
+	ParamNames   []string    // :param names in the template, in registration order; filled by trieNode.insert
+	WildcardName string      // *wildcard name in the template, if any; filled by trieNode.insert
 }
 
-// NewLambdaError is a constructor for creating a new LambdaError with code and message
-func NewLambdaError(code int, message string) LambdaError {
-	return &LambdaErrorResponse{
-		CodeValue:    code,
-		MessageValue: message,
+// buildParams zips this route's declared param/wildcard names against the
+// values the trie captured positionally for the matched path. Capturing by
+// position (rather than by name, on the shared trie node) is what lets two
+// routes with different HTTP methods share the same path shape under
+// different param names, e.g. GET "/users/:id" and POST "/users/:name".
+func (route *Route) buildParams(values []string, wildcard string) Params {
+	params := make(Params, len(route.ParamNames)+1)
+	for i, name := range route.ParamNames {
+		if i < len(values) {
+			params[name] = values[i]
+		}
 	}
-}
-
-// HandlerFunc defines the type for route handlers
-type HandlerFunc func(ctx context.Context, req events.LambdaFunctionURLRequest, params Params) (interface{}, LambdaError)
-
-// Route holds information about a single route
-type Route struct {
-	Method   string
-	Pattern  *regexp.Regexp
-	Handler  HandlerFunc
-	Template string
+	if route.WildcardName != "" {
+		params[route.WildcardName] = wildcard
+	}
+	return params
 }
 
 // Router manages routes and CORS configuration
 type Router struct {
-	routes  []*Route
-	Origins []string // Allowed origins, default is ["*"]
-	Methods []string // Allowed methods, default is all methods
-	Headers []string // Allowed headers, default is all headers
+	trie       *trieNode
+	middleware []Middleware
+	CORSConfig
 }
 
 // NewRouter creates a new Router instance with default CORS settings
 func NewRouter() *Router {
 	return &Router{
-		routes:  []*Route{},
-		Origins: []string{"*"}, // Allow all origins by default
-		Methods: []string{"*"}, // Allow all methods by default
-		Headers: []string{"*"}, // Allow all headers by default
+		trie: newTrieNode(),
+		CORSConfig: CORSConfig{
+			Origins: []string{"*"}, // Allow all origins by default
+			Methods: []string{"*"}, // Allow all methods by default
+			Headers: []string{"*"}, // Allow all headers by default
+		},
 	}
 }
 
-// AddRoute adds a new route to the router
-func (r *Router) AddRoute(method, path string, handler HandlerFunc) {
-	pattern := buildPathPattern(path)
-	r.routes = append(r.routes, &Route{
-		Method:   method,
-		Pattern:  pattern,
-		Handler:  handler,
-		Template: path,
+// AddRoute adds a new route to the router. Any middleware passed here runs
+// after the router's global middleware and wraps only this route's handler.
+func (r *Router) AddRoute(method, path string, handler HandlerFunc, mw ...Middleware) {
+	r.trie.insert(method, path, &Route{
+		Method:     method,
+		Handler:    handler,
+		Template:   path,
+		Middleware: mw,
 	})
 }
 
 // Shortcut methods
-func (r *Router) Get(path string, handler HandlerFunc)    { r.AddRoute("GET", path, handler) }
-func (r *Router) Post(path string, handler HandlerFunc)   { r.AddRoute("POST", path, handler) }
-func (r *Router) Put(path string, handler HandlerFunc)    { r.AddRoute("PUT", path, handler) }
-func (r *Router) Delete(path string, handler HandlerFunc) { r.AddRoute("DELETE", path, handler) }
+func (r *Router) Get(path string, handler HandlerFunc, mw ...Middleware) {
+	r.AddRoute("GET", path, handler, mw...)
+}
+func (r *Router) Post(path string, handler HandlerFunc, mw ...Middleware) {
+	r.AddRoute("POST", path, handler, mw...)
+}
+func (r *Router) Put(path string, handler HandlerFunc, mw ...Middleware) {
+	r.AddRoute("PUT", path, handler, mw...)
+}
+func (r *Router) Delete(path string, handler HandlerFunc, mw ...Middleware) {
+	r.AddRoute("DELETE", path, handler, mw...)
+}
 
-// HandleRequest is the main entry point for the Lambda function
-func (r *Router) HandleRequest(ctx context.Context, req events.LambdaFunctionURLRequest) (events.LambdaFunctionURLResponse, error) {
-	var response events.LambdaFunctionURLResponse
-	path, method := req.RequestContext.HTTP.Path, req.RequestContext.HTTP.Method
+// handle runs the normalized request through CORS, routing, and the
+// middleware chain. It's the common core behind every HandleXxx entry point.
+func (r *Router) handle(ctx context.Context, req Request) Response {
+	var response Response
 
 	// Handle CORS preflight requests
-	if method == "OPTIONS" {
-		return r.corsPreflightResponse(req), nil
+	if req.Method == "OPTIONS" {
+		return r.corsPreflightResponse(req)
 	}
 
 	// Match route
-	for _, route := range r.routes {
-		if route.Method == method && route.Pattern.MatchString(path) {
-			params := extractParams(path, route.Pattern)
-			data, err := route.Handler(ctx, req, params)
-			if err != nil {
-				return ErrorResponse(err), nil
-			}
-			response = SuccessResponse(data)
+	node, paramValues, wildcardValue := r.trie.lookup(req.Method, req.Path)
+
+	var matchedRoute *Route
+
+	switch {
+	case node != nil:
+		matchedRoute = node.routes[req.Method]
+		params := matchedRoute.buildParams(paramValues, wildcardValue)
+		handler := chain(chain(matchedRoute.Handler, matchedRoute.Middleware...), r.middleware...)
+		data, err := handler(ctx, req, params)
+		if err != nil {
+			response = ErrorResponse(req, err)
+		} else {
+			response = SuccessResponse(req, data)
+		}
+	default:
+		routes := r.trie.routesForPath(req.Path)
+		if len(routes) == 0 {
+			response = ErrorResponse(req, NotFound("route not found"))
 			break
 		}
-	}
-
-	// Route not found
-	if response.StatusCode == 0 {
-		response = events.LambdaFunctionURLResponse{
-			StatusCode: 404,
-			Body:       "Route not found",
+		allowed := make([]string, 0, len(routes))
+		for m := range routes {
+			allowed = append(allowed, m)
 		}
+		sort.Strings(allowed)
+		response = ErrorResponse(req, NewLambdaError(405, "method not allowed"))
+		response.Headers["Allow"] = strings.Join(allowed, ", ")
 	}
 
 	// Attach CORS headers
-	r.attachCORSHeaders(&response, req)
+	r.attachCORSHeaders(&response, req, matchedRoute)
 
-	return response, nil
-}
-
-// corsPreflightResponse handles preflight CORS requests
-func (r *Router) corsPreflightResponse(req events.LambdaFunctionURLRequest) events.LambdaFunctionURLResponse {
-	origin := req.Headers["origin"]
-	if !r.isOriginAllowed(origin) {
-		return events.LambdaFunctionURLResponse{
-			StatusCode: 403,
-			Body:       "Origin not allowed",
-		}
-	}
-	return events.LambdaFunctionURLResponse{
-		StatusCode: 200,
-		Headers: map[string]string{
-			"Access-Control-Allow-Origin":  origin,
-			"Access-Control-Allow-Methods": strings.Join(r.Methods, ", "),
-			"Access-Control-Allow-Headers": strings.Join(r.Headers, ", "),
-		},
-	}
-}
-
-// attachCORSHeaders adds CORS headers to responses
-func (r *Router) attachCORSHeaders(response *events.LambdaFunctionURLResponse, req events.LambdaFunctionURLRequest) {
-	origin := req.Headers["origin"]
-	if origin == "" || !r.isOriginAllowed(origin) {
-		origin = "*"
-	}
-
-	if response.Headers == nil {
-		response.Headers = make(map[string]string)
-	}
-	response.Headers["Access-Control-Allow-Origin"] = origin
-	response.Headers["Access-Control-Allow-Methods"] = strings.Join(r.Methods, ", ")
-	response.Headers["Access-Control-Allow-Headers"] = strings.Join(r.Headers, ", ")
-}
-
-// isOriginAllowed checks if the request origin is allowed
-func (r *Router) isOriginAllowed(origin string) bool {
-	if len(r.Origins) == 1 && r.Origins[0] == "*" {
-		return true
-	}
-	for _, allowedOrigin := range r.Origins {
-		if allowedOrigin == origin {
-			return true
-		}
-	}
-	return false
-}
-
-// Utility to generate error response
-func ErrorResponse(err LambdaError) events.LambdaFunctionURLResponse {
-	return events.LambdaFunctionURLResponse{
-		StatusCode: err.Code(),
-		Body:       fmt.Sprintf("Error: %s", err.Message()),
-	}
-}
-
-// Utility to generate success response
-func SuccessResponse(data interface{}) events.LambdaFunctionURLResponse {
-	body, _ := json.Marshal(data) // Ignoring errors for simplicity
-	return events.LambdaFunctionURLResponse{
-		StatusCode: 200,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-		Body: string(body),
-	}
+	return response
 }
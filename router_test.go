@@ -0,0 +1,149 @@
+package lambdahandler
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func ok(ctx context.Context, req Request, params Params) (interface{}, LambdaError) {
+	return params, nil
+}
+
+func TestRouterParamNamesAreResolvedPerRoute(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users/:id", ok)
+	r.Post("/users/:name", ok)
+
+	resp := r.handle(context.Background(), Request{Method: "POST", Path: "/users/alice"})
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+	if !strings.Contains(resp.Body, `"name":"alice"`) {
+		t.Fatalf("expected params[name]==alice, got body %s", resp.Body)
+	}
+	if strings.Contains(resp.Body, `"id"`) {
+		t.Fatalf("POST route should not see GET route's param name, got body %s", resp.Body)
+	}
+}
+
+// TestHandleBacktracksAcrossMethodsAtDifferentPathShapes is a regression
+// test for a bug where routing picked a branch (static > param > wildcard)
+// based only on path shape, so a static route registered for one method
+// incorrectly shadowed a param route registered for a different method at
+// the same path, returning 405 instead of matching the param route.
+func TestHandleBacktracksAcrossMethodsAtDifferentPathShapes(t *testing.T) {
+	r := NewRouter()
+	r.Get("/files/report", func(ctx context.Context, req Request, params Params) (interface{}, LambdaError) {
+		return "static", nil
+	})
+	r.Post("/files/:name", func(ctx context.Context, req Request, params Params) (interface{}, LambdaError) {
+		return params["name"], nil
+	})
+
+	resp := r.handle(context.Background(), Request{Method: "POST", Path: "/files/report"})
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+	if resp.Body != `"report"` {
+		t.Errorf(`expected POST to match the :name param route with name="report", got %s`, resp.Body)
+	}
+}
+
+func TestTriePrecedenceStaticBeatsParamBeatsWildcard(t *testing.T) {
+	r := NewRouter()
+	r.Get("/files/*rest", func(ctx context.Context, req Request, params Params) (interface{}, LambdaError) {
+		return "wildcard", nil
+	})
+	r.Get("/files/:name", func(ctx context.Context, req Request, params Params) (interface{}, LambdaError) {
+		return "param", nil
+	})
+	r.Get("/files/report", func(ctx context.Context, req Request, params Params) (interface{}, LambdaError) {
+		return "static", nil
+	})
+
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"/files/report", `"static"`},
+		{"/files/other", `"param"`},
+		{"/files/a/b/c", `"wildcard"`},
+	}
+	for _, tc := range cases {
+		resp := r.handle(context.Background(), Request{Method: "GET", Path: tc.path})
+		if resp.StatusCode != 200 || resp.Body != tc.want {
+			t.Errorf("path %s: expected %s, got %d %s", tc.path, tc.want, resp.StatusCode, resp.Body)
+		}
+	}
+}
+
+func TestTrieTrailingSlashIsNormalized(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users", ok)
+
+	for _, path := range []string{"/users", "/users/"} {
+		resp := r.handle(context.Background(), Request{Method: "GET", Path: path})
+		if resp.StatusCode != 200 {
+			t.Errorf("path %q: expected 200, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestHandleMethodNotAllowedListsAllowedMethods(t *testing.T) {
+	r := NewRouter()
+	r.Get("/widgets", ok)
+	r.Post("/widgets", ok)
+
+	resp := r.handle(context.Background(), Request{Method: "DELETE", Path: "/widgets"})
+	if resp.StatusCode != 405 {
+		t.Fatalf("expected 405, got %d", resp.StatusCode)
+	}
+	allowed := strings.Split(resp.Headers["Allow"], ", ")
+	sort.Strings(allowed)
+	if len(allowed) != 2 || allowed[0] != "GET" || allowed[1] != "POST" {
+		t.Fatalf("expected Allow: GET, POST, got %q", resp.Headers["Allow"])
+	}
+}
+
+func TestHandleNotFound(t *testing.T) {
+	r := NewRouter()
+	r.Get("/widgets", ok)
+
+	resp := r.handle(context.Background(), Request{Method: "GET", Path: "/nope"})
+	if resp.StatusCode != 404 {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestMiddlewareOrderingGlobalGroupRoute(t *testing.T) {
+	var order []string
+	track := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, req Request, params Params) (interface{}, LambdaError) {
+				order = append(order, name)
+				return next(ctx, req, params)
+			}
+		}
+	}
+
+	r := NewRouter()
+	r.Use(track("global"))
+	g := r.Group("/admin", track("group"))
+	g.Get("/ping", ok, track("route"))
+
+	resp := r.handle(context.Background(), Request{Method: "GET", Path: "/admin/ping"})
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	want := []string{"global", "group", "route"}
+	if len(order) != len(want) {
+		t.Fatalf("expected order %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, order)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package lambdahandler
+
+import "strings"
+
+// trieNode is a single path segment in the router's radix tree. Each method
+// registered against the exact path template that terminates at a node is
+// stored in routes, keyed by method. lookup only returns a node whose routes
+// hold the requested method; routesForPath is what tells "no route for this
+// path" apart from "route exists, wrong method".
+//
+// Param and wildcard segments are captured positionally, not by name: two
+// routes sharing the same path shape under different methods (e.g. GET
+// "/users/:id" and POST "/users/:name") share the same param/wildcard
+// child node, so the node can't own a single name for all of them. Each
+// Route records its own ParamNames/WildcardName (set during insert) and
+// buildParams zips those against the positionally-captured values once the
+// method has picked a specific route.
+type trieNode struct {
+	static   map[string]*trieNode
+	param    *trieNode
+	wildcard *trieNode
+	routes   map[string]*Route
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{static: make(map[string]*trieNode)}
+}
+
+// insert adds route to the tree under method for the given path template,
+// recording route's param/wildcard names in registration order.
+func (n *trieNode) insert(method, path string, route *Route) {
+	cur := n
+	var paramNames []string
+	wildcardName := ""
+
+	for _, seg := range splitPath(path) {
+		switch {
+		case strings.HasPrefix(seg, "*"):
+			if cur.wildcard == nil {
+				cur.wildcard = newTrieNode()
+			}
+			cur = cur.wildcard
+			wildcardName = seg[1:]
+		case strings.HasPrefix(seg, ":"):
+			if cur.param == nil {
+				cur.param = newTrieNode()
+			}
+			cur = cur.param
+			paramNames = append(paramNames, seg[1:])
+		default:
+			child, ok := cur.static[seg]
+			if !ok {
+				child = newTrieNode()
+				cur.static[seg] = child
+			}
+			cur = child
+		}
+	}
+
+	if cur.routes == nil {
+		cur.routes = make(map[string]*Route)
+	}
+	route.ParamNames = paramNames
+	route.WildcardName = wildcardName
+	cur.routes[method] = route
+}
+
+// lookup walks the tree for path and returns the terminal node that has a
+// route registered for method (nil if no branch of the tree has method at
+// the position path resolves to), the positionally captured param values in
+// registration order, and the captured wildcard remainder (empty if the
+// match didn't end in a wildcard).
+//
+// Matching backtracks across static/param/wildcard branches based on
+// whether method is present at each candidate terminal node, not merely on
+// whether the path shape matches: a static branch that matches the path but
+// doesn't have method must not shadow a param/wildcard branch that does
+// (e.g. GET "/files/report" and POST "/files/:name" are different routes
+// that happen to overlap in path shape). Callers that need to know whether
+// the path matched *some* route under a different method (to answer with
+// 405 instead of 404) should use routesForPath instead.
+func (n *trieNode) lookup(method, path string) (*trieNode, []string, string) {
+	return n.match(method, splitPath(path), nil)
+}
+
+func (n *trieNode) match(method string, segments []string, captured []string) (*trieNode, []string, string) {
+	if len(segments) == 0 {
+		if n.routes != nil && n.routes[method] != nil {
+			return n, captured, ""
+		}
+		return nil, nil, ""
+	}
+
+	seg, rest := segments[0], segments[1:]
+
+	if child, ok := n.static[seg]; ok {
+		if m, values, wildcard := child.match(method, rest, captured); m != nil {
+			return m, values, wildcard
+		}
+	}
+
+	if n.param != nil {
+		// Copy before appending: captured is shared across sibling branches
+		// during backtracking, and append may otherwise overwrite another
+		// branch's values through a reused backing array.
+		next := make([]string, len(captured), len(captured)+1)
+		copy(next, captured)
+		next = append(next, seg)
+		if m, values, wildcard := n.param.match(method, rest, next); m != nil {
+			return m, values, wildcard
+		}
+	}
+
+	if n.wildcard != nil && n.wildcard.routes != nil && n.wildcard.routes[method] != nil {
+		return n.wildcard, captured, strings.Join(segments, "/")
+	}
+
+	return nil, nil, ""
+}
+
+// routesForPath returns the union of all routes registered anywhere the
+// path shape matches, keyed by method, regardless of which method a caller
+// is asking about. It's used to tell a 404 (no route at all) apart from a
+// 405 (route exists under a different method) and, for CORS preflight, to
+// find a candidate route when the real request method isn't known yet.
+func (n *trieNode) routesForPath(path string) map[string]*Route {
+	routes := make(map[string]*Route)
+	n.collectRoutes(splitPath(path), routes)
+	return routes
+}
+
+func (n *trieNode) collectRoutes(segments []string, into map[string]*Route) {
+	if len(segments) == 0 {
+		for method, route := range n.routes {
+			if _, exists := into[method]; !exists {
+				into[method] = route
+			}
+		}
+		return
+	}
+
+	seg, rest := segments[0], segments[1:]
+
+	if child, ok := n.static[seg]; ok {
+		child.collectRoutes(rest, into)
+	}
+	if n.param != nil {
+		n.param.collectRoutes(rest, into)
+	}
+	if n.wildcard != nil {
+		for method, route := range n.wildcard.routes {
+			if _, exists := into[method]; !exists {
+				into[method] = route
+			}
+		}
+	}
+}
+
+// splitPath breaks a URL path into its non-empty segments.
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
@@ -0,0 +1,108 @@
+package lambdahandler
+
+import "testing"
+
+func TestTrieInsertLookupParamCollision(t *testing.T) {
+	root := newTrieNode()
+	root.insert("GET", "/users/:id", &Route{Method: "GET"})
+	root.insert("POST", "/users/:name", &Route{Method: "POST"})
+
+	getNode, getValues, _ := root.lookup("GET", "/users/alice")
+	if getNode == nil {
+		t.Fatal("expected a GET match for /users/alice")
+	}
+	if got := getNode.routes["GET"].buildParams(getValues, "")["id"]; got != "alice" {
+		t.Errorf("GET route: expected params[id]=alice, got %q", got)
+	}
+
+	postNode, postValues, _ := root.lookup("POST", "/users/alice")
+	if postNode == nil {
+		t.Fatal("expected a POST match for /users/alice")
+	}
+	postRoute := postNode.routes["POST"]
+	if got := postRoute.buildParams(postValues, "")["name"]; got != "alice" {
+		t.Errorf("POST route: expected params[name]=alice, got %q", got)
+	}
+	if _, ok := postRoute.buildParams(postValues, "")["id"]; ok {
+		t.Error("POST route should not have a param named id")
+	}
+}
+
+// TestTrieMatchBacktracksAcrossBranchesByMethod is a regression test for a
+// bug where matching picked a branch (static > param > wildcard) based only
+// on whether some method was registered at the terminal node, so a static
+// route registered for one method shadowed a param/wildcard route
+// registered for a different method at the same path shape.
+func TestTrieMatchBacktracksAcrossBranchesByMethod(t *testing.T) {
+	root := newTrieNode()
+	root.insert("GET", "/files/report", &Route{Method: "GET"})
+	root.insert("POST", "/files/:name", &Route{Method: "POST"})
+
+	getNode, _, _ := root.lookup("GET", "/files/report")
+	if getNode == nil || getNode.routes["GET"] == nil {
+		t.Fatal("expected GET /files/report to match the static route")
+	}
+
+	postNode, values, _ := root.lookup("POST", "/files/report")
+	if postNode == nil {
+		t.Fatal("expected POST /files/report to fall through to the :name param route")
+	}
+	if got := postNode.routes["POST"].buildParams(values, "")["name"]; got != "report" {
+		t.Errorf("expected params[name]=report, got %q", got)
+	}
+}
+
+func TestTrieBacktrackingDoesNotCorruptSiblingCapture(t *testing.T) {
+	root := newTrieNode()
+	root.insert("POST", "/a/:x/b/:y", &Route{Method: "POST"})
+	root.insert("GET", "/a/:x/:z/c", &Route{Method: "GET"})
+
+	// GET /a/one/b/c first follows the static "b" branch and captures "c"
+	// for :y, finds only POST there, then backtracks into the sibling :z
+	// branch.
+	node, values, _ := root.lookup("GET", "/a/one/b/c")
+	if node == nil {
+		t.Fatal("expected a match for /a/one/b/c")
+	}
+	route := node.routes["GET"]
+	if got := route.buildParams(values, "")["x"]; got != "one" {
+		t.Errorf("expected params[x]=one, got %q", got)
+	}
+	if got := route.buildParams(values, "")["z"]; got != "b" {
+		t.Errorf("expected params[z]=b, got %q", got)
+	}
+}
+
+func TestTrieWildcardCapturesRemainder(t *testing.T) {
+	root := newTrieNode()
+	root.insert("GET", "/files/*rest", &Route{Method: "GET"})
+
+	node, _, wildcard := root.lookup("GET", "/files/a/b/c")
+	if node == nil {
+		t.Fatal("expected a match for /files/a/b/c")
+	}
+	if wildcard != "a/b/c" {
+		t.Errorf("expected wildcard remainder a/b/c, got %q", wildcard)
+	}
+}
+
+func TestTrieLookupMissingPathReturnsNilNode(t *testing.T) {
+	root := newTrieNode()
+	root.insert("GET", "/users/:id", &Route{Method: "GET"})
+
+	node, _, _ := root.lookup("GET", "/other")
+	if node != nil {
+		t.Error("expected no match for an unregistered path")
+	}
+}
+
+func TestTrieRoutesForPathCollectsAcrossBranches(t *testing.T) {
+	root := newTrieNode()
+	root.insert("GET", "/files/report", &Route{Method: "GET"})
+	root.insert("POST", "/files/:name", &Route{Method: "POST"})
+
+	routes := root.routesForPath("/files/report")
+	if routes["GET"] == nil || routes["POST"] == nil {
+		t.Fatalf("expected routesForPath to union methods across branches, got %v", routes)
+	}
+}